@@ -5,13 +5,12 @@
 package wolframalpha
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"regexp"
-	"strings"
 )
 
 //APIKey will set the api key used for all queries to k. Intended to only be
@@ -36,54 +35,134 @@ func KeepParens(k bool) {
 	keepParens = k
 }
 
-//AskQuestionSpokenSync will send the query q to the wolframalpha spoken results
-//API and return the result. Will error if the connection fails
-func AskQuestionSpokenSync(q string) (r string, e error) {
-	resp, e := http.Get(fmt.Sprintf(spokenResultsURL, url.QueryEscape(q), apikey))
+//HTTPClient sets the *http.Client used for all queries to c, replacing the
+//default client (http.DefaultClient). Use this to configure per-application
+//timeouts and transports. Intended to only be used once, before any queries
+//are made
+func HTTPClient(c *http.Client) {
+	if c == nil {
+		return
+	}
+	httpClient = c
+}
 
+//doGet performs a GET against url, honoring ctx for both the dial and the
+//body read, and returns the response body along with its HTTP status code
+func doGet(ctx context.Context, fetchURL string) (b []byte, status int, e error) {
+	req, e := http.NewRequestWithContext(ctx, http.MethodGet, fetchURL, nil)
 	if e != nil {
-		return "", e
+		return nil, 0, e
 	}
 
+	resp, e := httpClient.Do(req)
+	if e != nil {
+		return nil, 0, e
+	}
 	defer resp.Body.Close()
 
-	bytes, e := ioutil.ReadAll(resp.Body)
-	return string(bytes), e
+	b, e = ioutil.ReadAll(resp.Body)
+	return b, resp.StatusCode, e
+}
+
+//AskQuestionSpokenContext will send the query q to the wolframalpha spoken
+//results API and return the result. Will error if the connection fails or ctx
+//is canceled before the response body is fully read
+func AskQuestionSpokenContext(ctx context.Context, q string) (r string, e error) {
+	bytes, status, e := doGet(ctx, fmt.Sprintf(spokenResultsURL, url.QueryEscape(q), apikey))
+	if e != nil {
+		return "", e
+	}
+	if se := errForStatus(status); se != nil {
+		return "", se
+	}
+	return string(bytes), nil
+}
+
+//AskQuestionSpokenSync will send the query q to the wolframalpha spoken results
+//API and return the result. Will error if the connection fails
+func AskQuestionSpokenSync(q string) (r string, e error) {
+	return AskQuestionSpokenContext(context.Background(), q)
 }
 
 //AskQuestionSpoken will send the answer to query q on the returned channel.
 //Will close channel after sending response.
-//Will send empty string if the connection fails.
-func AskQuestionSpoken(q string) <-chan string {
+//Will send empty string if the connection fails or ctx is canceled.
+func AskQuestionSpoken(ctx context.Context, q string) <-chan string {
 	r := make(chan string)
 	go func() {
-		a, e := AskQuestionSpokenSync(q)
+		defer close(r)
+		a, e := AskQuestionSpokenContext(ctx, q)
 		if e != nil {
-			r <- ""
-			close(r)
+			select {
+			case r <- "":
+			case <-ctx.Done():
+			}
 			return
 		}
-		r <- a
-		close(r)
+		select {
+		case r <- a:
+		case <-ctx.Done():
+		}
 	}()
 	return r
 }
 
-//AskQuestionJSONSync will return a []byte containing the full result in JSON
-//format. Will error if the connection fails or the JSON result is somehow
-//malformed
-func AskQuestionJSONSync(q string) (j []byte, e error) {
-	resp, e := http.Get(fmt.Sprintf(fullResultsURL, url.QueryEscape(q), apikey))
+//AskQuestionShortContext will send the query q to the wolframalpha "short
+//answers" API (/v1/result) and return a single-line plaintext answer. Will
+//error if the connection fails or ctx is canceled
+func AskQuestionShortContext(ctx context.Context, q string) (r string, e error) {
+	bytes, status, e := doGet(ctx, fmt.Sprintf(shortResultsURL, url.QueryEscape(q), apikey))
 	if e != nil {
-		return nil, e
+		return "", e
+	}
+	if se := errForStatus(status); se != nil {
+		return "", se
 	}
+	return string(bytes), nil
+}
 
-	defer resp.Body.Close()
+//AskQuestionShortSync will send the query q to the wolframalpha "short
+//answers" API (/v1/result) and return a single-line plaintext answer. Will
+//error if the connection fails
+func AskQuestionShortSync(q string) (r string, e error) {
+	return AskQuestionShortContext(context.Background(), q)
+}
+
+//AskQuestionShort will send the short answer to query q on the returned
+//channel. Will close channel after sending response.
+//Will send empty string if the connection fails or ctx is canceled.
+func AskQuestionShort(ctx context.Context, q string) <-chan string {
+	r := make(chan string)
+	go func() {
+		defer close(r)
+		a, e := AskQuestionShortContext(ctx, q)
+		if e != nil {
+			select {
+			case r <- "":
+			case <-ctx.Done():
+			}
+			return
+		}
+		select {
+		case r <- a:
+		case <-ctx.Done():
+		}
+	}()
+	return r
+}
 
-	j, e = ioutil.ReadAll(resp.Body)
+//AskQuestionJSONContext will return a []byte containing the full result in
+//JSON format. Will error if the connection fails, ctx is canceled before the
+//response body is fully read, or the JSON result is somehow malformed
+func AskQuestionJSONContext(ctx context.Context, q string) (j []byte, e error) {
+	var status int
+	j, status, e = doGet(ctx, fmt.Sprintf(fullResultsURL, url.QueryEscape(q), apikey))
 	if e != nil {
 		return nil, e
 	}
+	if se := errForStatus(status); se != nil {
+		return nil, se
+	}
 	if !json.Valid(j) {
 		return nil, errInvalidJSON
 	}
@@ -91,52 +170,90 @@ func AskQuestionJSONSync(q string) (j []byte, e error) {
 	return
 }
 
+//AskQuestionJSONSync will return a []byte containing the full result in JSON
+//format. Will error if the connection fails or the JSON result is somehow
+//malformed
+func AskQuestionJSONSync(q string) (j []byte, e error) {
+	return AskQuestionJSONContext(context.Background(), q)
+}
+
 //AskQuestionJSON will send a []byte containing the full result in JSON format
 //to the returned channel. Will close the channel after sending response.
 //Will send a nil slice if the connection fails or the JSON result is somehow
 //malformed
-func AskQuestionJSON(q string) <-chan []byte {
+func AskQuestionJSON(ctx context.Context, q string) <-chan []byte {
 	j := make(chan []byte)
 	go func() {
-		jsonRes, e := AskQuestionJSONSync(q)
+		defer close(j)
+		jsonRes, e := AskQuestionJSONContext(ctx, q)
 		if e != nil {
-			j <- nil
-			close(j)
+			select {
+			case j <- nil:
+			case <-ctx.Done():
+			}
 			return
 		}
-		j <- jsonRes
-		close(j)
+		select {
+		case j <- jsonRes:
+		case <-ctx.Done():
+		}
 	}()
 	return j
 }
 
+//AskQuestionContext will return a *FullResult containing the answer to query q
+//as returned from wolframalpha. Will error if the connection fails, ctx is
+//canceled, or the underlying JSON was malformed.
+//If the decoded response reports that the query itself failed (a QError such
+//as a bad AppID, no result, or an ambiguous/misunderstood input), r is still
+//returned alongside a *QueryError so callers can inspect r.QueryResult (e.g.
+//Assumptions or Didyoumeans), read the *QueryError via errors.As, or react to
+//the sentinel (ErrInvalidAppID, ErrAmbiguous, ErrDidYouMean, ...) via
+//errors.Is. An HTTP-level rejection (403, 429, 501) returns a nil r
+func AskQuestionContext(ctx context.Context, q string) (r *FullResult, e error) {
+	jsonRes, e := AskQuestionJSONContext(ctx, q)
+	if e != nil {
+		return nil, e
+	}
+	r, e = DecodeJSON(jsonRes)
+	if e != nil {
+		return nil, e
+	}
+	r.query = q
+	if qe := classifyResult(r.QueryResult); qe != nil {
+		return r, qe
+	}
+	return r, nil
+}
+
 //AskQuestionSync will return a *fullResult containing the answer to query q as
 //returned from wolframalpha
 //Will error if the connection fails or the underlying JSON was malformed
 func AskQuestionSync(q string) (r *FullResult, e error) {
-	jsonRes, e := AskQuestionJSONSync(q)
-	if e != nil {
-		return nil, e
-	}
-	return DecodeJSON(jsonRes)
+	return AskQuestionContext(context.Background(), q)
 }
 
 //AskQuestion will send a *fullResult containing the answer to query q on the
 //returned channel. Will close channel after sending response.
-//Will send a nil result if the connection fails or the underlying JSON was
-//malformed
-func AskQuestion(q string) <-chan *FullResult {
+//Will send a nil result if the connection fails, ctx is canceled, or the
+//underlying JSON was malformed
+func AskQuestion(ctx context.Context, q string) <-chan *FullResult {
 	r := make(chan *FullResult)
 	go func() {
-		result, e := AskQuestionSync(q)
+		defer close(r)
+		result, e := AskQuestionContext(ctx, q)
 		if e != nil {
-			r <- nil
-			close(r)
+			select {
+			case r <- nil:
+			case <-ctx.Done():
+			}
 			return
 		}
 
-		r <- result
-		close(r)
+		select {
+		case r <- result:
+		case <-ctx.Done():
+		}
 	}()
 	return r
 }
@@ -186,18 +303,35 @@ func (q *QueryResult) RemoveInputInterpretation() *QueryResult {
 }
 
 //GetAnswer will return either the numerical answer (value and unit combined) -
-//if present - or if not, the longest answer instead
+//if present - or if not, the longest answer instead. Uses the extractor set
+//via SetAnswerExtractor (the default numerical heuristic, unless changed).
 //If either fails, GetAnswer will return the error and no other values
 func (f *FullResult) GetAnswer() (s string, e error) {
 	return f.QueryResult.GetAnswer()
 }
 
 //GetAnswer will return either the numerical answer (value and unit combined) -
-//if present - or if not, the longest answer instead.
+//if present - or if not, the longest answer instead. Uses the extractor set
+//via SetAnswerExtractor (the default numerical heuristic, unless changed).
 //If either fails, GetAnswer will return the error and no other values
 func (q *QueryResult) GetAnswer() (s string, e error) {
-	num, unit, err := q.GetNumericalAnswer()
-	s = fmt.Sprintf("%s %s", num, unit)
+	return q.GetAnswerWith(currentExtractor)
+}
+
+//GetAnswerWith behaves like GetAnswer but scores subpods using extractor
+//instead of the package-wide default
+func (f *FullResult) GetAnswerWith(extractor AnswerExtractor) (s string, e error) {
+	return f.QueryResult.GetAnswerWith(extractor)
+}
+
+//GetAnswerWith behaves like GetAnswer but scores subpods using extractor
+//instead of the package-wide default
+func (q *QueryResult) GetAnswerWith(extractor AnswerExtractor) (s string, e error) {
+	num, unit, err := q.getNumericalAnswerWith(extractor)
+	s = num
+	if unit != "" {
+		s = fmt.Sprintf("%s %s", num, unit)
+	}
 
 	//predictable and "safe" error. none of our answers were numerical, or we
 	//found a date, so we return the longest one (or error)
@@ -212,7 +346,7 @@ func (q *QueryResult) GetAnswer() (s string, e error) {
 		return "", err
 	}
 
-	return
+	return s, nil
 }
 
 //GetLongestAnswer will return the longest answer contained in any subpod in the
@@ -253,13 +387,18 @@ func (f *FullResult) GetNumericalAnswer() (v string, u string, e error) {
 }
 
 //GetNumericalAnswer will return the value v and unit u (if present) as strings
-//in the first matching subpod. A subpod matches if its {.PlainText} field
-//contains >= 10% numbers (i.e. 10% of the "words" (split by whitespace) are
-//numerical)
+//in the first matching subpod, as scored by the extractor set via
+//SetAnswerExtractor (the default numerical heuristic, unless changed).
 //Prefers date answers over numbers. If a date is found, `errIsProbablyDate`
 //will be returned
 //Will error if no numbers are found or there are no pods in the result
 func (q *QueryResult) GetNumericalAnswer() (v string, u string, e error) {
+	return q.getNumericalAnswerWith(currentExtractor)
+}
+
+//getNumericalAnswerWith is the shared implementation behind
+//GetNumericalAnswer and GetAnswerWith
+func (q *QueryResult) getNumericalAnswerWith(extractor AnswerExtractor) (v string, u string, e error) {
 	if q.Numpods == 0 {
 		return "", "", errNoPods
 	}
@@ -271,9 +410,15 @@ func (q *QueryResult) GetNumericalAnswer() (v string, u string, e error) {
 				return "", "", errIsProbablyDate
 			}
 
-			found, v, u, e := analyzeSubPodForNumericalAnswer(sp)
+			found, a, err := extractor.Extract(sp, p.Infos)
 			if found {
-				return v, u, e
+				if err != nil {
+					return "", "", err
+				}
+				if a.IsRange {
+					return fmt.Sprintf("%s to %s", a.Low, a.High), a.Unit, nil
+				}
+				return a.Value, a.Unit, nil
 			}
 		}
 	}
@@ -283,52 +428,6 @@ func (q *QueryResult) GetNumericalAnswer() (v string, u string, e error) {
 	return "", "", errNoLikely
 }
 
-//───Helpers────────────────────────────────────────────────────────────────────
-
-//checks if a subpod contains a numerical answer, i.e. more than
-//{numericalAnswerProbability}% (10%) of words match the number regex.
-func analyzeSubPodForNumericalAnswer(sp SubPod) (found bool, v string, u string, e error) {
-	words := strings.Split(removeParens(sp.PlainText), " ")
-	numOfWords := len(words)
-	countNumbers := 0
-
-	//count words in the text
-	for _, w := range words {
-		if isNumber(w) {
-			countNumbers++
-		}
-	}
-
-	if float32(countNumbers)/float32(numOfWords) < numericalAnswerProbability {
-		//not enough words are numbers, return not found (probably continue with the
-		//next subpod
-		return false, "", "", nil
-	}
-
-	matches := numberFinder.FindStringIndex(sp.PlainText)
-
-	if matches == nil {
-		return true, "", "", errNoMatch
-	}
-
-	idx := 0
-	for i, word := range words {
-		if isNumber(word) {
-			idx = i
-			break
-		}
-	}
-
-	v = words[idx]
-
-	//find unit
-	if idx+1 < len(words) {
-		u = words[idx+1]
-	}
-
-	return true, v, u, e
-}
-
 //if keepParens is set, the returned string will have text in parens removed.
 //E.g. foo (bar) -> foo
 func removeParens(s string) string {
@@ -338,66 +437,6 @@ func removeParens(s string) string {
 	return parenRemover(s, "")
 }
 
-func isNumber(s string) bool {
-	return numericalRgx.MatchString(s)
-}
-
-//true if [words]... contains word
-func has(word string, words ...string) bool {
-	for _, x := range words {
-		if word == x {
-			return true
-		}
-	}
-	return false
-}
-
-//true if {dateAnswerProbability}% (30%) of words in a string are "date words",
-//i.e. weekdays or months (written out)
-func isLongDateAnswer(s string) bool {
-	words := strings.Split(
-		//remove special characters first, then split by words
-		regexp.MustCompile(`[-.,!?]`).ReplaceAllString(s, ""),
-		" ")
-
-	if len(words) == 0 {
-		return false
-	}
-
-	weekdays := []string{
-		"Monday",
-		"Tuesday",
-		"Wednesday",
-		"Thursday",
-		"Friday",
-		"Saturday",
-		"Sunday",
-	}
-	months := []string{
-		"January",
-		"February",
-		"March",
-		"April",
-		"May",
-		"June",
-		"July",
-		"August",
-		"September",
-		"October",
-		"November",
-		"December",
-	}
-	datewords := 0
-
-	for _, word := range words {
-		if has(word, weekdays...) || has(word, months...) {
-			datewords++
-		}
-	}
-
-	return float32(datewords)/float32(len(words)) > dateAnswerProbability
-}
-
 //ForEach will apply the given function f to every pod in the slice
 func (ps Pods) ForEach(f func(Pod)) {
 	for _, p := range ps {
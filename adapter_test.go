@@ -0,0 +1,127 @@
+//This Source Code Form is subject to the terms of the Mozilla Public
+//License, v. 2.0. If a copy of the MPL was not distributed with this
+//file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package wolframalpha
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+//redirectingTransport rewrites every request to target's scheme/host before
+//delegating to the real transport, so tests can point the package's
+//hardcoded wolframalpha.com URLs at a local httptest.Server
+type redirectingTransport struct {
+	target *url.URL
+}
+
+func (rt *redirectingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+//withTestServer points the package's shared http.Client at ts for the
+//duration of the test, restoring the previous client on cleanup
+func withTestServer(t *testing.T, ts *httptest.Server) {
+	t.Helper()
+	target, e := url.Parse(ts.URL)
+	if e != nil {
+		t.Fatalf("parsing test server URL: %v", e)
+	}
+
+	previous := httpClient
+	HTTPClient(&http.Client{Transport: &redirectingTransport{target: target}})
+	t.Cleanup(func() { httpClient = previous })
+}
+
+func TestDoGetContextDeadlineExceeded(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Write([]byte("too late"))
+	}))
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, _, e := doGet(ctx, ts.URL); e == nil {
+		t.Fatal("expected a context deadline error, got nil")
+	}
+}
+
+//closesPromptlyWithin is the bound every channel variant must close within
+//once ctx is already canceled - comfortably above scheduling noise, far below
+//a hang
+const closesPromptlyWithin = 2 * time.Second
+
+func TestChannelVariantsClosePromptlyOnCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	t.Run("AskQuestion", func(t *testing.T) {
+		select {
+		case r, ok := <-AskQuestion(ctx, "test"):
+			if ok && r != nil {
+				t.Fatalf("expected nil result, got %+v", r)
+			}
+		case <-time.After(closesPromptlyWithin):
+			t.Fatal("channel did not close/send promptly after ctx cancellation")
+		}
+	})
+
+	t.Run("AskQuestionJSON", func(t *testing.T) {
+		select {
+		case j, ok := <-AskQuestionJSON(ctx, "test"):
+			if ok && j != nil {
+				t.Fatalf("expected nil result, got %v", j)
+			}
+		case <-time.After(closesPromptlyWithin):
+			t.Fatal("channel did not close/send promptly after ctx cancellation")
+		}
+	})
+
+	t.Run("AskQuestionSpoken", func(t *testing.T) {
+		select {
+		case s, ok := <-AskQuestionSpoken(ctx, "test"):
+			if ok && s != "" {
+				t.Fatalf("expected empty result, got %q", s)
+			}
+		case <-time.After(closesPromptlyWithin):
+			t.Fatal("channel did not close/send promptly after ctx cancellation")
+		}
+	})
+
+	t.Run("AskQuestionShort", func(t *testing.T) {
+		select {
+		case s, ok := <-AskQuestionShort(ctx, "test"):
+			if ok && s != "" {
+				t.Fatalf("expected empty result, got %q", s)
+			}
+		case <-time.After(closesPromptlyWithin):
+			t.Fatal("channel did not close/send promptly after ctx cancellation")
+		}
+	})
+}
+
+func TestAskQuestionContextUsesSharedHTTPClient(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"queryresult":{"success":true,"error":false,"numpods":0,"pods":[]}}`))
+	}))
+	defer ts.Close()
+	withTestServer(t, ts)
+
+	r, e := AskQuestionContext(context.Background(), "2+2")
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+	if r == nil || !r.QueryResult.Success {
+		t.Fatalf("expected a successful result, got %+v", r)
+	}
+}
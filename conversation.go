@@ -0,0 +1,105 @@
+//This Source Code Form is subject to the terms of the Mozilla Public
+//License, v. 2.0. If a copy of the MPL was not distributed with this
+//file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package wolframalpha
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+)
+
+//Conversation is a stateful session against the wolframalpha conversational
+//API (/v1/conversation.jsp). Each turn carries the conversationID, s, and
+//host returned by the previous one, so follow-up queries (e.g. "how long
+//would it take to drive there") resolve in context.
+//Use NewConversation to create one; the zero value starts a fresh
+//conversation against api.wolframalpha.com. Safe for concurrent use; turns
+//are serialized since each one depends on the state the previous one left.
+type Conversation struct {
+	mu sync.Mutex
+
+	conversationID string
+	host           string
+	s              int
+}
+
+//NewConversation returns a Conversation ready for its first turn
+func NewConversation() *Conversation {
+	return &Conversation{host: conversationHost}
+}
+
+func (c *Conversation) requestURL(q string) string {
+	v := url.Values{}
+	v.Set("i", q)
+	v.Set("appid", apikey)
+	if c.conversationID != "" {
+		v.Set("conversationid", c.conversationID)
+		v.Set("s", strconv.Itoa(c.s))
+	}
+
+	host := c.host
+	if host == "" {
+		host = conversationHost
+	}
+
+	return fmt.Sprintf("https://%s%s?%s", host, conversationPath, v.Encode())
+}
+
+//AskSync sends q as the next turn of the conversation and returns its result
+//text. Will error if the connection fails, ctx is canceled, or the response
+//couldn't be decoded into a ConversationTurn
+func (c *Conversation) AskSync(ctx context.Context, q string) (r string, e error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	b, status, e := doGet(ctx, c.requestURL(q))
+	if e != nil {
+		return "", e
+	}
+	if se := errForStatus(status); se != nil {
+		return "", se
+	}
+
+	var turn ConversationTurn
+	if e = json.Unmarshal(b, &turn); e != nil {
+		return "", e
+	}
+	if turn.ErrorMsg != "" {
+		return "", &QueryError{Msg: turn.ErrorMsg, sentinel: ErrNoResult}
+	}
+
+	c.conversationID = turn.ConversationID
+	c.host = turn.Host
+	c.s = turn.S
+
+	return turn.Result, nil
+}
+
+//Ask sends q as the next turn of the conversation and sends its result text
+//on the returned channel. Will close channel after sending response.
+//Will send empty string if the connection fails, ctx is canceled, or the
+//response couldn't be decoded.
+func (c *Conversation) Ask(ctx context.Context, q string) <-chan string {
+	r := make(chan string)
+	go func() {
+		defer close(r)
+		a, e := c.AskSync(ctx, q)
+		if e != nil {
+			select {
+			case r <- "":
+			case <-ctx.Done():
+			}
+			return
+		}
+		select {
+		case r <- a:
+		case <-ctx.Done():
+		}
+	}()
+	return r
+}
@@ -0,0 +1,136 @@
+//This Source Code Form is subject to the terms of the Mozilla Public
+//License, v. 2.0. If a copy of the MPL was not distributed with this
+//file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package wolframalpha
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestAskQuestionShortContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("42"))
+	}))
+	defer ts.Close()
+	withTestServer(t, ts)
+
+	r, e := AskQuestionShortContext(context.Background(), "6 times 7")
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+	if r != "42" {
+		t.Fatalf("expected %q, got %q", "42", r)
+	}
+}
+
+func TestConversationCarriesStateAcrossTurns(t *testing.T) {
+	turn := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		turn++
+		q := r.URL.Query()
+		switch turn {
+		case 1:
+			if q.Get("conversationid") != "" {
+				t.Errorf("first turn should not carry a conversationid, got %q", q.Get("conversationid"))
+			}
+			w.Write([]byte(`{"result":"2790 miles","conversationID":"abc123","host":"api.wolframalpha.com","s":1}`))
+		case 2:
+			if q.Get("conversationid") != "abc123" {
+				t.Errorf("expected conversationid=abc123, got %q", q.Get("conversationid"))
+			}
+			if q.Get("s") != "1" {
+				t.Errorf("expected s=1, got %q", q.Get("s"))
+			}
+			w.Write([]byte(`{"result":"about 41 hours","conversationID":"abc123","host":"api.wolframalpha.com","s":2}`))
+		default:
+			t.Fatalf("unexpected third turn")
+		}
+	}))
+	defer ts.Close()
+	withTestServer(t, ts)
+
+	c := NewConversation()
+
+	r1, e := c.AskSync(context.Background(), "how far is LA from NYC")
+	if e != nil {
+		t.Fatalf("unexpected error on first turn: %v", e)
+	}
+	if r1 != "2790 miles" {
+		t.Fatalf("expected %q, got %q", "2790 miles", r1)
+	}
+
+	r2, e := c.AskSync(context.Background(), "how long would it take to drive there")
+	if e != nil {
+		t.Fatalf("unexpected error on second turn: %v", e)
+	}
+	if r2 != "about 41 hours" {
+		t.Fatalf("expected %q, got %q", "about 41 hours", r2)
+	}
+}
+
+//hostRecordingTransport records the host each request targeted before
+//rewriting it to target's scheme/host, so tests can assert which host
+//Conversation.requestURL picked while still delegating the actual request to
+//a local httptest.Server
+type hostRecordingTransport struct {
+	target *url.URL
+	hosts  *[]string
+}
+
+func (rt *hostRecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	*rt.hosts = append(*rt.hosts, req.URL.Host)
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestConversationSwitchesHostAcrossTurns(t *testing.T) {
+	turn := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		turn++
+		switch turn {
+		case 1:
+			w.Write([]byte(`{"result":"2790 miles","conversationID":"abc123","host":"weights.wolframalpha.com","s":1}`))
+		case 2:
+			w.Write([]byte(`{"result":"about 41 hours","conversationID":"abc123","host":"weights.wolframalpha.com","s":2}`))
+		default:
+			t.Fatalf("unexpected third turn")
+		}
+	}))
+	defer ts.Close()
+
+	target, e := url.Parse(ts.URL)
+	if e != nil {
+		t.Fatalf("parsing test server URL: %v", e)
+	}
+
+	var hosts []string
+	previous := httpClient
+	HTTPClient(&http.Client{Transport: &hostRecordingTransport{target: target, hosts: &hosts}})
+	t.Cleanup(func() { httpClient = previous })
+
+	c := NewConversation()
+
+	if _, e := c.AskSync(context.Background(), "how far is LA from NYC"); e != nil {
+		t.Fatalf("unexpected error on first turn: %v", e)
+	}
+	if _, e := c.AskSync(context.Background(), "how long would it take to drive there"); e != nil {
+		t.Fatalf("unexpected error on second turn: %v", e)
+	}
+
+	if len(hosts) != 2 {
+		t.Fatalf("expected 2 requests, got %d: %v", len(hosts), hosts)
+	}
+	if hosts[0] != conversationHost {
+		t.Fatalf("expected first turn to target %q, got %q", conversationHost, hosts[0])
+	}
+	if hosts[1] != "weights.wolframalpha.com" {
+		t.Fatalf("expected second turn to target the host returned by the first, got %q", hosts[1])
+	}
+}
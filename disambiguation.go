@@ -0,0 +1,111 @@
+//This Source Code Form is subject to the terms of the Mozilla Public
+//License, v. 2.0. If a copy of the MPL was not distributed with this
+//file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package wolframalpha
+
+import (
+	"context"
+	"sort"
+	"strconv"
+)
+
+//AssumptionChoice is one way wolframalpha offers to disambiguate a query,
+//taken from either an AssumptionValue or a DidYouMean
+type AssumptionChoice struct {
+	//Desc describes the choice (e.g. "a weekday" or "a song"). Empty for
+	//choices derived from a DidYouMean, which only carry corrected input.
+	Desc string
+
+	//Input is the value to feed back into the query to select this choice:
+	//either an AssumptionValue.Input (used as an `assumption` parameter) or
+	//a DidYouMean.Val (used as replacement input)
+	Input string
+
+	//replacesInput is true for choices derived from a DidYouMean, whose Val
+	//replaces the query input outright rather than being passed as an
+	//`assumption` parameter
+	replacesInput bool
+}
+
+//Assumptions returns the ways wolframalpha offered to disambiguate this
+//result's query: one choice per QueryResult.Assumptions value, followed by
+//one choice per QueryResult.Didyoumeans (best-scored first). Returns nil if
+//there's nothing to disambiguate.
+func (f *FullResult) Assumptions() []AssumptionChoice {
+	if f == nil || f.QueryResult == nil {
+		return nil
+	}
+
+	qr := f.QueryResult
+	choices := make([]AssumptionChoice, 0, len(qr.Didyoumeans))
+	if qr.Assumptions != nil {
+		for _, v := range qr.Assumptions.Values {
+			choices = append(choices, AssumptionChoice{Desc: v.Desc, Input: v.Input})
+		}
+	}
+
+	dym := append(DidYouMeans{}, qr.Didyoumeans...)
+	sort.SliceStable(dym, func(i, j int) bool {
+		return parseScore(dym[i].Score) > parseScore(dym[j].Score)
+	})
+	for _, d := range dym {
+		choices = append(choices, AssumptionChoice{Input: d.Val, replacesInput: true})
+	}
+
+	if len(choices) == 0 {
+		return nil
+	}
+	return choices
+}
+
+//ResolveWith re-issues the query that produced f with choice applied: either
+//as an `assumption` parameter (for a choice taken from Assumptions), or as
+//the query input outright (for a choice taken from a DidYouMean). Preserves
+//any QueryOptions f was originally queried with.
+func (f *FullResult) ResolveWith(ctx context.Context, choice AssumptionChoice) (*FullResult, error) {
+	if f == nil {
+		return nil, ErrNoResult
+	}
+
+	if choice.replacesInput {
+		return AskQuestionContextOpts(ctx, choice.Input, f.opts)
+	}
+
+	opts := &QueryOptions{}
+	if f.opts != nil {
+		*opts = *f.opts
+	}
+	opts.Assumption = append(append([]string{}, opts.Assumption...), choice.Input)
+
+	return AskQuestionContextOpts(ctx, f.query, opts)
+}
+
+//BestGuess turns wolframalpha's disambiguation into a one-liner: it resolves
+//with the first of f.Assumptions() (an Assumptions value if any are present,
+//otherwise the top-scoring DidYouMean). Returns f unchanged if it was already
+//successful with nothing to resolve.
+func (f *FullResult) BestGuess(ctx context.Context) (*FullResult, error) {
+	if f == nil || f.QueryResult == nil {
+		return nil, ErrNoResult
+	}
+
+	if choices := f.Assumptions(); len(choices) > 0 {
+		return f.ResolveWith(ctx, choices[0])
+	}
+
+	if f.QueryResult.Success {
+		return f, nil
+	}
+
+	return nil, ErrNoResult
+}
+
+//parseScore reads a DidYouMean.Score, defaulting to 0 if it isn't a number
+func parseScore(s string) float64 {
+	score, e := strconv.ParseFloat(s, 64)
+	if e != nil {
+		return 0
+	}
+	return score
+}
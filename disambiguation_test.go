@@ -0,0 +1,223 @@
+//This Source Code Form is subject to the terms of the Mozilla Public
+//License, v. 2.0. If a copy of the MPL was not distributed with this
+//file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package wolframalpha
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFullResultAssumptions(t *testing.T) {
+	cases := []struct {
+		name  string
+		f     *FullResult
+		check func(t *testing.T, choices []AssumptionChoice)
+	}{
+		{
+			name: "nil result",
+			f:    nil,
+			check: func(t *testing.T, choices []AssumptionChoice) {
+				if choices != nil {
+					t.Fatalf("expected nil, got %+v", choices)
+				}
+			},
+		},
+		{
+			name: "didyoumeans only, sorted best-scored first",
+			f: &FullResult{QueryResult: &QueryResult{
+				Didyoumeans: DidYouMeans{
+					{Val: "lower score", Score: "0.4"},
+					{Val: "higher score", Score: "0.8"},
+				},
+			}},
+			check: func(t *testing.T, choices []AssumptionChoice) {
+				if len(choices) != 2 {
+					t.Fatalf("expected 2 choices, got %+v", choices)
+				}
+				if choices[0].Input != "higher score" || !choices[0].replacesInput {
+					t.Fatalf("expected higher-scored DidYouMean first, got %+v", choices[0])
+				}
+				if choices[1].Input != "lower score" || !choices[1].replacesInput {
+					t.Fatalf("expected lower-scored DidYouMean second, got %+v", choices[1])
+				}
+			},
+		},
+		{
+			name: "assumptions only",
+			f: &FullResult{QueryResult: &QueryResult{
+				Assumptions: &Assumptions{Values: AssumptionValues{
+					{Desc: "a weekday", Input: "*C.weekday-_**Monday--"},
+				}},
+			}},
+			check: func(t *testing.T, choices []AssumptionChoice) {
+				if len(choices) != 1 {
+					t.Fatalf("expected 1 choice, got %+v", choices)
+				}
+				if choices[0].Desc != "a weekday" || choices[0].Input != "*C.weekday-_**Monday--" || choices[0].replacesInput {
+					t.Fatalf("unexpected choice: %+v", choices[0])
+				}
+			},
+		},
+		{
+			name: "assumptions before didyoumeans",
+			f: &FullResult{QueryResult: &QueryResult{
+				Assumptions: &Assumptions{Values: AssumptionValues{
+					{Desc: "a weekday", Input: "*C.weekday-_**Monday--"},
+				}},
+				Didyoumeans: DidYouMeans{{Val: "corrected", Score: "1.0"}},
+			}},
+			check: func(t *testing.T, choices []AssumptionChoice) {
+				if len(choices) != 2 {
+					t.Fatalf("expected 2 choices, got %+v", choices)
+				}
+				if choices[0].replacesInput {
+					t.Fatalf("expected the Assumptions choice first, got %+v", choices[0])
+				}
+				if !choices[1].replacesInput || choices[1].Input != "corrected" {
+					t.Fatalf("expected the DidYouMean choice second, got %+v", choices[1])
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.check(t, c.f.Assumptions())
+		})
+	}
+}
+
+func TestFullResultResolveWith(t *testing.T) {
+	t.Run("nil result", func(t *testing.T) {
+		var f *FullResult
+		if _, e := f.ResolveWith(context.Background(), AssumptionChoice{}); e != ErrNoResult {
+			t.Fatalf("expected ErrNoResult, got %v", e)
+		}
+	})
+
+	t.Run("didyoumean choice replaces the query input", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("input"); got != "corrected input" {
+				t.Errorf("expected input=%q, got %q", "corrected input", got)
+			}
+			if _, ok := r.URL.Query()["assumption"]; ok {
+				t.Errorf("expected no assumption parameter, got %v", r.URL.Query()["assumption"])
+			}
+			w.Write([]byte(`{"queryresult":{"success":true,"error":false,"numpods":0,"pods":[]}}`))
+		}))
+		defer ts.Close()
+		withTestServer(t, ts)
+
+		f := &FullResult{QueryResult: &QueryResult{}, query: "original input"}
+		r, e := f.ResolveWith(context.Background(), AssumptionChoice{Input: "corrected input", replacesInput: true})
+		if e != nil {
+			t.Fatalf("unexpected error: %v", e)
+		}
+		if r == nil || !r.QueryResult.Success {
+			t.Fatalf("expected a successful result, got %+v", r)
+		}
+	})
+
+	t.Run("assumption choice is appended as an assumption parameter", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			if got := q.Get("input"); got != "original input" {
+				t.Errorf("expected input=%q, got %q", "original input", got)
+			}
+			if got := q["assumption"]; len(got) != 1 || got[0] != "*C.weekday-_**Monday--" {
+				t.Errorf("unexpected assumption values: %v", got)
+			}
+			w.Write([]byte(`{"queryresult":{"success":true,"error":false,"numpods":0,"pods":[]}}`))
+		}))
+		defer ts.Close()
+		withTestServer(t, ts)
+
+		f := &FullResult{QueryResult: &QueryResult{}, query: "original input"}
+		choice := AssumptionChoice{Desc: "a weekday", Input: "*C.weekday-_**Monday--"}
+		r, e := f.ResolveWith(context.Background(), choice)
+		if e != nil {
+			t.Fatalf("unexpected error: %v", e)
+		}
+		if r == nil || !r.QueryResult.Success {
+			t.Fatalf("expected a successful result, got %+v", r)
+		}
+	})
+}
+
+func TestFullResultBestGuess(t *testing.T) {
+	t.Run("nil result", func(t *testing.T) {
+		var f *FullResult
+		if _, e := f.BestGuess(context.Background()); e != ErrNoResult {
+			t.Fatalf("expected ErrNoResult, got %v", e)
+		}
+	})
+
+	t.Run("already successful with nothing to resolve returns f unchanged", func(t *testing.T) {
+		f := &FullResult{QueryResult: &QueryResult{Success: true}}
+		r, e := f.BestGuess(context.Background())
+		if e != nil {
+			t.Fatalf("unexpected error: %v", e)
+		}
+		if r != f {
+			t.Fatalf("expected f to be returned unchanged, got %+v", r)
+		}
+	})
+
+	t.Run("no result and nothing to resolve", func(t *testing.T) {
+		f := &FullResult{QueryResult: &QueryResult{Success: false}}
+		if _, e := f.BestGuess(context.Background()); e != ErrNoResult {
+			t.Fatalf("expected ErrNoResult, got %v", e)
+		}
+	})
+
+	t.Run("resolves with the top-scoring DidYouMean", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if got := r.URL.Query().Get("input"); got != "corrected" {
+				t.Errorf("expected input=%q, got %q", "corrected", got)
+			}
+			w.Write([]byte(`{"queryresult":{"success":true,"error":false,"numpods":0,"pods":[]}}`))
+		}))
+		defer ts.Close()
+		withTestServer(t, ts)
+
+		f := &FullResult{
+			QueryResult: &QueryResult{
+				Success: false,
+				Didyoumeans: DidYouMeans{
+					{Val: "corrected", Score: "0.9"},
+					{Val: "worse guess", Score: "0.1"},
+				},
+			},
+			query: "original",
+		}
+		r, e := f.BestGuess(context.Background())
+		if e != nil {
+			t.Fatalf("unexpected error: %v", e)
+		}
+		if r == nil || !r.QueryResult.Success {
+			t.Fatalf("expected a successful result, got %+v", r)
+		}
+	})
+}
+
+func TestParseScore(t *testing.T) {
+	cases := []struct {
+		score string
+		want  float64
+	}{
+		{"0.9", 0.9},
+		{"1", 1},
+		{"not a number", 0},
+		{"", 0},
+	}
+
+	for _, c := range cases {
+		if got := parseScore(c.score); got != c.want {
+			t.Errorf("parseScore(%q) = %v, want %v", c.score, got, c.want)
+		}
+	}
+}
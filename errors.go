@@ -0,0 +1,148 @@
+//This Source Code Form is subject to the terms of the Mozilla Public
+//License, v. 2.0. If a copy of the MPL was not distributed with this
+//file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package wolframalpha
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	//ErrInvalidAppID is returned when wolframalpha rejects the configured
+	//AppID, either via an HTTP 403 or a QError mentioning the appid
+	ErrInvalidAppID = errors.New("wolframalpha: invalid appid")
+
+	//ErrQueryInterrupted is returned when wolframalpha reports that
+	//processing the query was interrupted (HTTP 501, or a matching QError)
+	ErrQueryInterrupted = errors.New("wolframalpha: query processing was interrupted")
+
+	//ErrNoResult is returned when the query succeeded but wolframalpha could
+	//not produce a result and offered no Assumptions or DidYouMeans to act on
+	ErrNoResult = errors.New("wolframalpha: query did not produce a result")
+
+	//ErrRateLimited is returned on an HTTP 429 from the API
+	ErrRateLimited = errors.New("wolframalpha: rate limited by the api")
+
+	//ErrAmbiguous is returned when the query failed but wolframalpha offered
+	//Assumptions to disambiguate it. Use (*FullResult).Assumptions and
+	//(*FullResult).ResolveWith to re-issue the query
+	ErrAmbiguous = errors.New("wolframalpha: input is ambiguous, see Assumptions")
+
+	//ErrDidYouMean is returned when the query failed but wolframalpha offered
+	//DidYouMeans spelling corrections. Use (*FullResult).BestGuess to
+	//re-issue the query with the top suggestion
+	ErrDidYouMean = errors.New("wolframalpha: input not understood, see DidYouMeans")
+)
+
+//QueryError wraps a failure reported by the wolframalpha API, either at the
+//HTTP level (HTTPStatus set, Code/Msg empty) or inside the decoded
+//QueryResult (Code/Msg set from QError). errors.Is matches QueryError against
+//the sentinel (ErrInvalidAppID, etc.) it was classified as.
+//You should not instantiate this yourself.
+type QueryError struct {
+	Code       string
+	Msg        string
+	HTTPStatus int
+
+	sentinel error
+}
+
+func (e *QueryError) Error() string {
+	switch {
+	case e.HTTPStatus != 0 && e.Msg != "":
+		return fmt.Sprintf("wolframalpha: http %d: %s", e.HTTPStatus, e.Msg)
+	case e.HTTPStatus != 0:
+		return fmt.Sprintf("wolframalpha: http %d", e.HTTPStatus)
+	case e.Code != "":
+		return fmt.Sprintf("wolframalpha: %s (code %s)", e.Msg, e.Code)
+	default:
+		return fmt.Sprintf("wolframalpha: %s", e.Msg)
+	}
+}
+
+//Unwrap lets errors.Is/errors.As match e against the sentinel it was
+//classified as (e.g. ErrInvalidAppID)
+func (e *QueryError) Unwrap() error {
+	return e.sentinel
+}
+
+//errForStatus maps the HTTP status codes wolframalpha is documented to use
+//for outright rejection to a *QueryError. Returns nil for any other status.
+func errForStatus(status int) error {
+	switch status {
+	case 403:
+		return &QueryError{HTTPStatus: status, sentinel: ErrInvalidAppID}
+	case 429:
+		return &QueryError{HTTPStatus: status, sentinel: ErrRateLimited}
+	case 501:
+		return &QueryError{HTTPStatus: status, sentinel: ErrQueryInterrupted}
+	default:
+		return nil
+	}
+}
+
+//sentinelForQError classifies a QError reported inside a QueryResult by
+//inspecting its message, since wolframalpha's error codes aren't documented
+//to be stable
+func sentinelForQError(qe *QError) error {
+	msg := strings.ToLower(qe.Msg)
+	switch {
+	case strings.Contains(msg, "appid"):
+		return ErrInvalidAppID
+	case strings.Contains(msg, "interrupt"):
+		return ErrQueryInterrupted
+	default:
+		return ErrNoResult
+	}
+}
+
+//UnmarshalJSON lets ErrorUnion decode wolframalpha's "error" field, which is
+//either the bool `false` (the normal, successful case) or a QError object
+//(e.g. {"code":"1000","msg":"Invalid appid"})
+func (eu *ErrorUnion) UnmarshalJSON(data []byte) error {
+	var b bool
+	if e := json.Unmarshal(data, &b); e == nil {
+		eu.Bool = &b
+		return nil
+	}
+
+	var qe QError
+	if e := json.Unmarshal(data, &qe); e != nil {
+		return e
+	}
+	eu.Error = &qe
+	return nil
+}
+
+//classifyResult inspects qr for the ways wolframalpha reports a query that
+//didn't produce a usable answer, returning a *QueryError wrapping the
+//appropriate sentinel, or nil if qr represents a usable result
+func classifyResult(qr *QueryResult) error {
+	if qr == nil {
+		return &QueryError{Msg: "response did not include a queryresult", sentinel: ErrNoResult}
+	}
+
+	if qr.Error != nil && qr.Error.Error != nil {
+		qe := qr.Error.Error
+		return &QueryError{Code: qe.Code, Msg: qe.Msg, sentinel: sentinelForQError(qe)}
+	}
+
+	if qr.Success {
+		return nil
+	}
+
+	switch {
+	case len(qr.Didyoumeans) > 0:
+		return &QueryError{Msg: "input not understood, see DidYouMeans", sentinel: ErrDidYouMean}
+	case qr.Assumptions != nil && len(qr.Assumptions.Values) > 0:
+		return &QueryError{Msg: "input is ambiguous, see Assumptions", sentinel: ErrAmbiguous}
+	case qr.Tips != nil:
+		return &QueryError{Msg: qr.Tips.Text, sentinel: ErrNoResult}
+	default:
+		return &QueryError{Msg: "query did not produce a result", sentinel: ErrNoResult}
+	}
+}
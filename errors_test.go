@@ -0,0 +1,180 @@
+//This Source Code Form is subject to the terms of the Mozilla Public
+//License, v. 2.0. If a copy of the MPL was not distributed with this
+//file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package wolframalpha
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestErrorUnionUnmarshalJSON(t *testing.T) {
+	cases := []struct {
+		name    string
+		data    string
+		wantErr bool
+		check   func(t *testing.T, eu ErrorUnion)
+	}{
+		{
+			name: "success bool",
+			data: `false`,
+			check: func(t *testing.T, eu ErrorUnion) {
+				if eu.Bool == nil || *eu.Bool != false {
+					t.Fatalf("expected Bool=false, got %+v", eu)
+				}
+				if eu.Error != nil {
+					t.Fatalf("expected Error=nil, got %+v", eu.Error)
+				}
+			},
+		},
+		{
+			name: "qerror object",
+			data: `{"code":"1000","msg":"Invalid appid"}`,
+			check: func(t *testing.T, eu ErrorUnion) {
+				if eu.Bool != nil {
+					t.Fatalf("expected Bool=nil, got %+v", eu)
+				}
+				if eu.Error == nil || eu.Error.Code != "1000" || eu.Error.Msg != "Invalid appid" {
+					t.Fatalf("expected decoded QError, got %+v", eu.Error)
+				}
+			},
+		},
+		{
+			name:    "malformed",
+			data:    `["not", "valid"]`,
+			wantErr: true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var eu ErrorUnion
+			e := json.Unmarshal([]byte(c.data), &eu)
+			if c.wantErr {
+				if e == nil {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if e != nil {
+				t.Fatalf("unexpected error: %v", e)
+			}
+			c.check(t, eu)
+		})
+	}
+}
+
+func TestErrForStatus(t *testing.T) {
+	cases := []struct {
+		status  int
+		wantErr error
+	}{
+		{403, ErrInvalidAppID},
+		{429, ErrRateLimited},
+		{501, ErrQueryInterrupted},
+		{200, nil},
+		{500, nil},
+	}
+
+	for _, c := range cases {
+		e := errForStatus(c.status)
+		if c.wantErr == nil {
+			if e != nil {
+				t.Errorf("status %d: expected nil, got %v", c.status, e)
+			}
+			continue
+		}
+		if !errors.Is(e, c.wantErr) {
+			t.Errorf("status %d: expected errors.Is(%v, %v), got false", c.status, e, c.wantErr)
+		}
+	}
+}
+
+func TestClassifyResult(t *testing.T) {
+	cases := []struct {
+		name    string
+		qr      *QueryResult
+		wantErr error //nil means classifyResult should return nil
+	}{
+		{
+			name:    "nil queryresult",
+			qr:      nil,
+			wantErr: ErrNoResult,
+		},
+		{
+			name:    "success",
+			qr:      &QueryResult{Success: true},
+			wantErr: nil,
+		},
+		{
+			name: "qerror invalid appid",
+			qr: &QueryResult{
+				Error: &ErrorUnion{Error: &QError{Code: "1000", Msg: "Invalid appid"}},
+			},
+			wantErr: ErrInvalidAppID,
+		},
+		{
+			name: "qerror interrupted",
+			qr: &QueryResult{
+				Error: &ErrorUnion{Error: &QError{Code: "1", Msg: "Query processing was interrupted"}},
+			},
+			wantErr: ErrQueryInterrupted,
+		},
+		{
+			name: "didyoumeans",
+			qr: &QueryResult{
+				Success:     false,
+				Didyoumeans: DidYouMeans{{Val: "foo", Score: "0.9"}},
+			},
+			wantErr: ErrDidYouMean,
+		},
+		{
+			name: "assumptions",
+			qr: &QueryResult{
+				Success:     false,
+				Assumptions: &Assumptions{Values: AssumptionValues{{Input: "*C.Monday--"}}},
+			},
+			wantErr: ErrAmbiguous,
+		},
+		{
+			name: "tips",
+			qr: &QueryResult{
+				Success: false,
+				Tips:    &Tips{Text: "Check your spelling"},
+			},
+			wantErr: ErrNoResult,
+		},
+		{
+			name:    "no result, no extra info",
+			qr:      &QueryResult{Success: false},
+			wantErr: ErrNoResult,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			e := classifyResult(c.qr)
+			if c.wantErr == nil {
+				if e != nil {
+					t.Fatalf("expected nil, got %v", e)
+				}
+				return
+			}
+			if !errors.Is(e, c.wantErr) {
+				t.Fatalf("expected errors.Is(%v, %v), got false", e, c.wantErr)
+			}
+		})
+	}
+}
+
+func TestDecodeJSONSuccessRoundtrip(t *testing.T) {
+	r, e := DecodeJSONString(`{"queryresult":{"success":true,"error":false,"numpods":0,"pods":[]}}`)
+	if e != nil {
+		t.Fatalf("unexpected decode error: %v", e)
+	}
+	if e := classifyResult(r.QueryResult); e != nil {
+		t.Fatalf("expected a successful response to classify as nil, got %v", e)
+	}
+}
@@ -0,0 +1,208 @@
+//This Source Code Form is subject to the terms of the Mozilla Public
+//License, v. 2.0. If a copy of the MPL was not distributed with this
+//file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package wolframalpha
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+//ExtractedAnswer is the value an AnswerExtractor found in a subpod: either a
+//single Value (with optional Unit), or - if IsRange - a Low/High pair.
+type ExtractedAnswer struct {
+	Value string
+	Unit  string
+	Low   string
+	High  string
+
+	IsRange bool
+}
+
+//String renders a as GetAnswer/GetNumericalAnswer would
+func (a ExtractedAnswer) String() string {
+	switch {
+	case a.IsRange && a.Unit != "":
+		return fmt.Sprintf("%s to %s %s", a.Low, a.High, a.Unit)
+	case a.IsRange:
+		return fmt.Sprintf("%s to %s", a.Low, a.High)
+	case a.Unit != "":
+		return fmt.Sprintf("%s %s", a.Value, a.Unit)
+	default:
+		return a.Value
+	}
+}
+
+//AnswerExtractor scores a single subpod for a numerical-ish answer. infos is
+//the parent Pod's Infos, used to prefer wolframalpha's own Units over
+//guessing a unit from the surrounding words.
+//Implementations should return found=false (not an error) when sp simply
+//isn't the kind of answer they look for, so GetAnswerWith can keep trying
+//later subpods.
+type AnswerExtractor interface {
+	Extract(sp SubPod, infos *PodInfos) (found bool, answer ExtractedAnswer, e error)
+}
+
+var currentExtractor AnswerExtractor = DefaultExtractor{}
+
+//SetAnswerExtractor changes the AnswerExtractor used by GetAnswer and
+//GetNumericalAnswer. Intended to only be used once, before any queries are
+//made. Use (*QueryResult).GetAnswerWith instead to use a different extractor
+//for a single call.
+func SetAnswerExtractor(e AnswerExtractor) {
+	if e == nil {
+		return
+	}
+	currentExtractor = e
+}
+
+//DefaultExtractor is the extractor GetAnswer/GetNumericalAnswer use unless
+//SetAnswerExtractor is called. A subpod matches if >= numericalAnswerProbability
+//(10%) of its "words" (split by whitespace) are numerical; the unit is taken
+//from the pod's PodInfos.Units when one matches the word following the
+//number, or when the pod carries exactly one unit, falling back to that next
+//word otherwise.
+type DefaultExtractor struct{}
+
+//Extract implements AnswerExtractor
+func (DefaultExtractor) Extract(sp SubPod, infos *PodInfos) (found bool, answer ExtractedAnswer, e error) {
+	words := strings.Split(removeParens(sp.PlainText), " ")
+	numOfWords := len(words)
+	if numOfWords == 0 {
+		return false, ExtractedAnswer{}, nil
+	}
+
+	countNumbers := 0
+	for _, w := range words {
+		if isNumber(w) {
+			countNumbers++
+		}
+	}
+
+	if float32(countNumbers)/float32(numOfWords) < numericalAnswerProbability {
+		//not enough words are numbers, return not found (probably continue with
+		//the next subpod)
+		return false, ExtractedAnswer{}, nil
+	}
+
+	if numberFinder.FindStringIndex(sp.PlainText) == nil {
+		return true, ExtractedAnswer{}, errNoMatch
+	}
+
+	idx := 0
+	for i, word := range words {
+		if isNumber(word) {
+			idx = i
+			break
+		}
+	}
+
+	return true, ExtractedAnswer{Value: words[idx], Unit: unitFor(infos, words, idx)}, nil
+}
+
+//unitFor picks the unit for the number at words[idx], preferring a
+//PodInfos.Unit that matches the following word, then the only PodInfos.Unit
+//if there is exactly one, over that word itself
+func unitFor(infos *PodInfos, words []string, idx int) string {
+	next := ""
+	if idx+1 < len(words) {
+		next = words[idx+1]
+	}
+
+	if infos != nil {
+		for _, unit := range infos.Units {
+			if next != "" && (next == unit.Short || next == unit.Long) {
+				return unit.Short
+			}
+		}
+		if len(infos.Units) == 1 {
+			return infos.Units[0].Short
+		}
+	}
+
+	return next
+}
+
+func isNumber(s string) bool {
+	return numericalRgx.MatchString(s)
+}
+
+var currencyPrefixRgx = regexp.MustCompile(`^[\p{Sc}]\s?(-?[\d,]*\.?\d+)`)
+
+//CurrencyExtractor matches subpods written as a currency symbol followed by a
+//number (e.g. "$12.50"), or - if the pod carries any PodInfos.Units - the
+//first number in the text with thousands separators removed (e.g. "1,234"
+//gives "1234"), paired with the first unit's Short form. The units are not
+//checked to be currencies.
+type CurrencyExtractor struct{}
+
+//Extract implements AnswerExtractor
+func (CurrencyExtractor) Extract(sp SubPod, infos *PodInfos) (found bool, answer ExtractedAnswer, e error) {
+	text := removeParens(sp.PlainText)
+
+	if m := currencyPrefixRgx.FindStringSubmatch(text); m != nil {
+		symbol := strings.TrimSpace(text[:strings.Index(text, m[1])])
+		return true, ExtractedAnswer{Value: m[1], Unit: symbol}, nil
+	}
+
+	if infos == nil || len(infos.Units) == 0 {
+		return false, ExtractedAnswer{}, nil
+	}
+
+	if m := numberFinder.FindString(strings.ReplaceAll(text, ",", "")); m != "" {
+		return true, ExtractedAnswer{Value: m, Unit: infos.Units[0].Short}, nil
+	}
+
+	return false, ExtractedAnswer{}, nil
+}
+
+var rangeRgx = regexp.MustCompile(`(-?[\d,]*\.?\d+)\s*(?:to|-|–)\s*(-?[\d,]*\.?\d+)\s*([\pL°%]*)`)
+
+//RangeExtractor matches subpods expressing a range, e.g. "3 to 5 inches".
+//Known limitation: the pattern it looks for (N <sep> N) doesn't distinguish
+//a genuine range from unrelated N-N text such as a day-of-month range
+//("March 3-5") or a year span ("2020-2023"), which it will also report as a
+//range with no unit. Prefer DefaultExtractor unless ranges are expected in
+//the pods you're reading.
+type RangeExtractor struct{}
+
+//Extract implements AnswerExtractor
+func (RangeExtractor) Extract(sp SubPod, infos *PodInfos) (found bool, answer ExtractedAnswer, e error) {
+	m := rangeRgx.FindStringSubmatch(removeParens(sp.PlainText))
+	if m == nil {
+		return false, ExtractedAnswer{}, nil
+	}
+
+	unit := m[3]
+	if unit == "" {
+		unit = unitFor(infos, nil, -1)
+	}
+
+	return true, ExtractedAnswer{Low: m[1], High: m[2], Unit: unit, IsRange: true}, nil
+}
+
+var (
+	iso8601Rgx     = regexp.MustCompile(`\d{4}-\d{2}-\d{2}(?:T[\d:.]+Z?)?`)
+	numericDateRgx = regexp.MustCompile(`\b\d{1,2}/\d{1,2}/\d{2,4}\b`)
+)
+
+//DateExtractor matches subpods containing an ISO 8601 or numeric (e.g.
+//"7/28/2026") date, as an alternative to the spelled-out dates
+//isLongDateAnswer looks for
+type DateExtractor struct{}
+
+//Extract implements AnswerExtractor
+func (DateExtractor) Extract(sp SubPod, infos *PodInfos) (found bool, answer ExtractedAnswer, e error) {
+	text := removeParens(sp.PlainText)
+
+	if m := iso8601Rgx.FindString(text); m != "" {
+		return true, ExtractedAnswer{Value: m}, nil
+	}
+	if m := numericDateRgx.FindString(text); m != "" {
+		return true, ExtractedAnswer{Value: m}, nil
+	}
+
+	return false, ExtractedAnswer{}, nil
+}
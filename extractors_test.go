@@ -0,0 +1,112 @@
+//This Source Code Form is subject to the terms of the Mozilla Public
+//License, v. 2.0. If a copy of the MPL was not distributed with this
+//file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package wolframalpha
+
+import "testing"
+
+func TestDefaultExtractorPrefersPodInfoUnit(t *testing.T) {
+	infos := &PodInfos{Units: []Unit{{Short: "mi", Long: "miles"}}}
+	sp := SubPod{PlainText: "2790 miles"}
+
+	found, a, e := DefaultExtractor{}.Extract(sp, infos)
+	if !found || e != nil {
+		t.Fatalf("expected a match, got found=%v err=%v", found, e)
+	}
+	if a.Value != "2790" || a.Unit != "mi" {
+		t.Fatalf("expected value=2790 unit=mi (from PodInfos), got %+v", a)
+	}
+}
+
+func TestDefaultExtractorFallsBackToNextWord(t *testing.T) {
+	sp := SubPod{PlainText: "2790 furlongs"}
+
+	found, a, e := DefaultExtractor{}.Extract(sp, nil)
+	if !found || e != nil {
+		t.Fatalf("expected a match, got found=%v err=%v", found, e)
+	}
+	if a.Value != "2790" || a.Unit != "furlongs" {
+		t.Fatalf("expected the next word as unit, got %+v", a)
+	}
+}
+
+func TestDefaultExtractorNotFoundBelowThreshold(t *testing.T) {
+	sp := SubPod{PlainText: "the quick brown fox jumps over lazy dogs while counting to 1"}
+
+	found, _, e := DefaultExtractor{}.Extract(sp, nil)
+	if found || e != nil {
+		t.Fatalf("expected no match below the numerical threshold, got found=%v err=%v", found, e)
+	}
+}
+
+func TestCurrencyExtractorPrefixSymbol(t *testing.T) {
+	found, a, e := CurrencyExtractor{}.Extract(SubPod{PlainText: "$12.50"}, nil)
+	if !found || e != nil {
+		t.Fatalf("expected a match, got found=%v err=%v", found, e)
+	}
+	if a.Value != "12.50" || a.Unit != "$" {
+		t.Fatalf("expected value=12.50 unit=$, got %+v", a)
+	}
+}
+
+func TestCurrencyExtractorUnitsWithThousandsSeparator(t *testing.T) {
+	infos := &PodInfos{Units: []Unit{{Short: "USD", Long: "United States dollars"}}}
+	found, a, e := CurrencyExtractor{}.Extract(SubPod{PlainText: "1,234"}, infos)
+	if !found || e != nil {
+		t.Fatalf("expected a match, got found=%v err=%v", found, e)
+	}
+	if a.Value != "1234" || a.Unit != "USD" {
+		t.Fatalf("expected value=1234 unit=USD, got %+v", a)
+	}
+}
+
+func TestRangeExtractor(t *testing.T) {
+	found, a, e := RangeExtractor{}.Extract(SubPod{PlainText: "3 to 5 inches"}, nil)
+	if !found || e != nil {
+		t.Fatalf("expected a match, got found=%v err=%v", found, e)
+	}
+	if !a.IsRange || a.Low != "3" || a.High != "5" {
+		t.Fatalf("expected a 3-5 range, got %+v", a)
+	}
+}
+
+func TestDateExtractorISO8601(t *testing.T) {
+	found, a, e := DateExtractor{}.Extract(SubPod{PlainText: "2026-07-28"}, nil)
+	if !found || e != nil {
+		t.Fatalf("expected a match, got found=%v err=%v", found, e)
+	}
+	if a.Value != "2026-07-28" {
+		t.Fatalf("expected the ISO date verbatim, got %+v", a)
+	}
+}
+
+func TestDateExtractorNumeric(t *testing.T) {
+	found, a, e := DateExtractor{}.Extract(SubPod{PlainText: "7/28/2026"}, nil)
+	if !found || e != nil {
+		t.Fatalf("expected a match, got found=%v err=%v", found, e)
+	}
+	if a.Value != "7/28/2026" {
+		t.Fatalf("expected the numeric date verbatim, got %+v", a)
+	}
+}
+
+func TestIsLongDateAnswerRespectsLocale(t *testing.T) {
+	defer SetLocale(englishLocale)
+
+	if !isLongDateAnswer("Tuesday, July 28, 2026") {
+		t.Fatal("expected the default (English) locale to recognize a spelled-out date")
+	}
+
+	SetLocale(Locale{
+		Weekdays: []string{"Dienstag"},
+		Months:   []string{"Juli"},
+	})
+
+	if isLongDateAnswer("Tuesday, July 28, 2026") {
+		t.Fatal("expected the English date to no longer match after switching locale")
+	}
+	if !isLongDateAnswer("Dienstag, 28 Juli 2026") {
+		t.Fatal("expected the German date to match the German locale")
+	}
+}
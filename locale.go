@@ -0,0 +1,89 @@
+//This Source Code Form is subject to the terms of the Mozilla Public
+//License, v. 2.0. If a copy of the MPL was not distributed with this
+//file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package wolframalpha
+
+import (
+	"regexp"
+	"strings"
+)
+
+//Locale carries the weekday/month word lists isLongDateAnswer uses to
+//recognize spelled-out dates in a given language. Set via SetLocale.
+type Locale struct {
+	Weekdays []string
+	Months   []string
+}
+
+var englishLocale = Locale{
+	Weekdays: []string{
+		"Monday",
+		"Tuesday",
+		"Wednesday",
+		"Thursday",
+		"Friday",
+		"Saturday",
+		"Sunday",
+	},
+	Months: []string{
+		"January",
+		"February",
+		"March",
+		"April",
+		"May",
+		"June",
+		"July",
+		"August",
+		"September",
+		"October",
+		"November",
+		"December",
+	},
+}
+
+var currentLocale = englishLocale
+
+//SetLocale changes the weekday/month word lists isLongDateAnswer matches
+//against, so results in languages other than English are recognized as dates.
+//Intended to only be used once, before any queries are made.
+func SetLocale(l Locale) {
+	if len(l.Weekdays) == 0 && len(l.Months) == 0 {
+		return
+	}
+	currentLocale = l
+}
+
+var specialCharRgx = regexp.MustCompile(`[-.,!?]`)
+
+//true if [words]... contains word
+func has(word string, words ...string) bool {
+	for _, x := range words {
+		if word == x {
+			return true
+		}
+	}
+	return false
+}
+
+//true if more than {dateAnswerProbability}% (30%) of words in a string are "date words",
+//i.e. weekdays or months (written out), per currentLocale
+func isLongDateAnswer(s string) bool {
+	words := strings.Split(
+		//remove special characters first, then split by words
+		specialCharRgx.ReplaceAllString(s, ""),
+		" ")
+
+	if len(words) == 0 {
+		return false
+	}
+
+	datewords := 0
+	for _, word := range words {
+		if has(word, currentLocale.Weekdays...) || has(word, currentLocale.Months...) {
+			datewords++
+		}
+	}
+
+	return float32(datewords)/float32(len(words)) > dateAnswerProbability
+}
@@ -0,0 +1,180 @@
+//This Source Code Form is subject to the terms of the Mozilla Public
+//License, v. 2.0. If a copy of the MPL was not distributed with this
+//file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package wolframalpha
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strings"
+)
+
+const queryBaseURL = "https://api.wolframalpha.com/v2/query"
+
+//QueryOptions exposes the query parameters accepted by the wolframalpha v2
+//query endpoint beyond the plain input string. A nil *QueryOptions behaves
+//like AskQuestionSync (format=plaintext, no other parameters set).
+//You should instantiate this yourself; zero values are omitted from the
+//request.
+type QueryOptions struct {
+	//Format lists the result formats to request (e.g. "plaintext", "image",
+	//"mathml", "sound", "wav", "minput", "moutput", "cell"). Defaults to
+	//"plaintext" if empty.
+	Format []string
+
+	//Assumption resolves ambiguity reported via Assumptions. Use the Input
+	//value of the chosen AssumptionValue (a DidYouMean's Val replaces the
+	//query input instead, see ResolveWith).
+	Assumption []string
+
+	PodState     []string
+	IncludePodID []string
+	ExcludePodID []string
+
+	Units    string
+	Location string
+	Latlong  string
+	IP       string
+
+	MaxWidth string
+
+	ScanTimeout   string
+	PodTimeout    string
+	FormatTimeout string
+	ParseTimeout  string
+	TotalTimeout  string
+}
+
+//values turns o into the url.Values wolframalpha expects, defaulting Format
+//to plaintext when unset
+func (o *QueryOptions) values() url.Values {
+	v := url.Values{}
+	if o == nil {
+		v.Set("format", "plaintext")
+		return v
+	}
+
+	if len(o.Format) > 0 {
+		v.Set("format", strings.Join(o.Format, ","))
+	} else {
+		v.Set("format", "plaintext")
+	}
+
+	for _, a := range o.Assumption {
+		v.Add("assumption", a)
+	}
+	for _, p := range o.PodState {
+		v.Add("podstate", p)
+	}
+	for _, id := range o.IncludePodID {
+		v.Add("includepodid", id)
+	}
+	for _, id := range o.ExcludePodID {
+		v.Add("excludepodid", id)
+	}
+
+	setIfNotEmpty(v, "units", o.Units)
+	setIfNotEmpty(v, "location", o.Location)
+	setIfNotEmpty(v, "latlong", o.Latlong)
+	setIfNotEmpty(v, "ip", o.IP)
+	setIfNotEmpty(v, "maxwidth", o.MaxWidth)
+	setIfNotEmpty(v, "scantimeout", o.ScanTimeout)
+	setIfNotEmpty(v, "podtimeout", o.PodTimeout)
+	setIfNotEmpty(v, "formattimeout", o.FormatTimeout)
+	setIfNotEmpty(v, "parsetimeout", o.ParseTimeout)
+	setIfNotEmpty(v, "totaltimeout", o.TotalTimeout)
+
+	return v
+}
+
+func setIfNotEmpty(v url.Values, key, val string) {
+	if val != "" {
+		v.Set(key, val)
+	}
+}
+
+//buildQueryURL assembles the v2/query URL for q under opts
+func buildQueryURL(q string, opts *QueryOptions) string {
+	v := opts.values()
+	v.Set("input", q)
+	v.Set("appid", apikey)
+	v.Set("output", "JSON")
+	return queryBaseURL + "?" + v.Encode()
+}
+
+//AskQuestionJSONContextOpts behaves like AskQuestionJSONContext but builds the
+//request from opts instead of the hardcoded format=plaintext query
+func AskQuestionJSONContextOpts(ctx context.Context, q string, opts *QueryOptions) (j []byte, e error) {
+	var status int
+	j, status, e = doGet(ctx, buildQueryURL(q, opts))
+	if e != nil {
+		return nil, e
+	}
+	if se := errForStatus(status); se != nil {
+		return nil, se
+	}
+	if !json.Valid(j) {
+		return nil, errInvalidJSON
+	}
+
+	return
+}
+
+//AskQuestionJSONSyncOpts behaves like AskQuestionJSONSync but builds the
+//request from opts instead of the hardcoded format=plaintext query
+func AskQuestionJSONSyncOpts(q string, opts *QueryOptions) (j []byte, e error) {
+	return AskQuestionJSONContextOpts(context.Background(), q, opts)
+}
+
+//AskQuestionContextOpts behaves like AskQuestionContext but builds the
+//request from opts instead of the hardcoded format=plaintext query
+func AskQuestionContextOpts(ctx context.Context, q string, opts *QueryOptions) (r *FullResult, e error) {
+	jsonRes, e := AskQuestionJSONContextOpts(ctx, q, opts)
+	if e != nil {
+		return nil, e
+	}
+	r, e = DecodeJSON(jsonRes)
+	if e != nil {
+		return nil, e
+	}
+	r.query = q
+	r.opts = opts
+	if qe := classifyResult(r.QueryResult); qe != nil {
+		return r, qe
+	}
+	return r, nil
+}
+
+//AskQuestionSyncOpts behaves like AskQuestionSync but builds the request from
+//opts, allowing assumptions, pod filtering, units, location, per-stage
+//timeouts, and non-plaintext formats (image, mathml, sound, wav, minput,
+//moutput, cell) to be requested
+func AskQuestionSyncOpts(q string, opts *QueryOptions) (r *FullResult, e error) {
+	return AskQuestionContextOpts(context.Background(), q, opts)
+}
+
+//AskQuestionOpts behaves like AskQuestion but builds the request from opts.
+//Will send a nil result if the connection fails, ctx is canceled, or the
+//underlying JSON was malformed
+func AskQuestionOpts(ctx context.Context, q string, opts *QueryOptions) <-chan *FullResult {
+	r := make(chan *FullResult)
+	go func() {
+		defer close(r)
+		result, e := AskQuestionContextOpts(ctx, q, opts)
+		if e != nil {
+			select {
+			case r <- nil:
+			case <-ctx.Done():
+			}
+			return
+		}
+
+		select {
+		case r <- result:
+		case <-ctx.Done():
+		}
+	}()
+	return r
+}
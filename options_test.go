@@ -0,0 +1,152 @@
+//This Source Code Form is subject to the terms of the Mozilla Public
+//License, v. 2.0. If a copy of the MPL was not distributed with this
+//file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package wolframalpha
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestQueryOptionsValues(t *testing.T) {
+	cases := []struct {
+		name  string
+		opts  *QueryOptions
+		check func(t *testing.T, v url.Values)
+	}{
+		{
+			name: "nil opts defaults to plaintext",
+			opts: nil,
+			check: func(t *testing.T, v url.Values) {
+				if v.Get("format") != "plaintext" {
+					t.Fatalf("expected format=plaintext, got %q", v.Get("format"))
+				}
+				if len(v) != 1 {
+					t.Fatalf("expected only format set, got %v", v)
+				}
+			},
+		},
+		{
+			name: "empty Format defaults to plaintext",
+			opts: &QueryOptions{},
+			check: func(t *testing.T, v url.Values) {
+				if v.Get("format") != "plaintext" {
+					t.Fatalf("expected format=plaintext, got %q", v.Get("format"))
+				}
+			},
+		},
+		{
+			name: "multiple formats are comma-joined",
+			opts: &QueryOptions{Format: []string{"plaintext", "image"}},
+			check: func(t *testing.T, v url.Values) {
+				if v.Get("format") != "plaintext,image" {
+					t.Fatalf("expected format=plaintext,image, got %q", v.Get("format"))
+				}
+			},
+		},
+		{
+			name: "repeated params are kept as multiple values",
+			opts: &QueryOptions{
+				Assumption:   []string{"*C.weekday-_**Monday--", "*C.weekday-_**Tuesday--"},
+				PodState:     []string{"Step-by-step solution"},
+				IncludePodID: []string{"Input", "Result"},
+				ExcludePodID: []string{"NotableFacts"},
+			},
+			check: func(t *testing.T, v url.Values) {
+				if got := v["assumption"]; len(got) != 2 || got[0] != "*C.weekday-_**Monday--" || got[1] != "*C.weekday-_**Tuesday--" {
+					t.Fatalf("unexpected assumption values: %v", got)
+				}
+				if got := v["podstate"]; len(got) != 1 || got[0] != "Step-by-step solution" {
+					t.Fatalf("unexpected podstate values: %v", got)
+				}
+				if got := v["includepodid"]; len(got) != 2 || got[0] != "Input" || got[1] != "Result" {
+					t.Fatalf("unexpected includepodid values: %v", got)
+				}
+				if got := v["excludepodid"]; len(got) != 1 || got[0] != "NotableFacts" {
+					t.Fatalf("unexpected excludepodid values: %v", got)
+				}
+			},
+		},
+		{
+			name: "scalar params are only set when non-empty",
+			opts: &QueryOptions{Units: "metric", Location: "Boston, MA", MaxWidth: "500"},
+			check: func(t *testing.T, v url.Values) {
+				if v.Get("units") != "metric" {
+					t.Fatalf("expected units=metric, got %q", v.Get("units"))
+				}
+				if v.Get("location") != "Boston, MA" {
+					t.Fatalf("expected location=Boston, MA, got %q", v.Get("location"))
+				}
+				if v.Get("maxwidth") != "500" {
+					t.Fatalf("expected maxwidth=500, got %q", v.Get("maxwidth"))
+				}
+				if _, ok := v["latlong"]; ok {
+					t.Fatalf("expected latlong to be omitted, got %v", v["latlong"])
+				}
+			},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.check(t, c.opts.values())
+		})
+	}
+}
+
+func TestAskQuestionSyncOptsSendsQueryParameters(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("format") != "plaintext,image" {
+			t.Errorf("expected format=plaintext,image, got %q", q.Get("format"))
+		}
+		if q.Get("units") != "metric" {
+			t.Errorf("expected units=metric, got %q", q.Get("units"))
+		}
+		if got := q["assumption"]; len(got) != 1 || got[0] != "*C.weekday-_**Monday--" {
+			t.Errorf("unexpected assumption values: %v", got)
+		}
+		w.Write([]byte(`{"queryresult":{"success":true,"error":false,"numpods":0,"pods":[]}}`))
+	}))
+	defer ts.Close()
+	withTestServer(t, ts)
+
+	opts := &QueryOptions{
+		Format:     []string{"plaintext", "image"},
+		Units:      "metric",
+		Assumption: []string{"*C.weekday-_**Monday--"},
+	}
+	r, e := AskQuestionSyncOpts("what day is it", opts)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+	if r == nil || !r.QueryResult.Success {
+		t.Fatalf("expected a successful result, got %+v", r)
+	}
+}
+
+func TestAskQuestionContextOptsNilOptsDefaultsToPlaintext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("format"); got != "plaintext" {
+			t.Errorf("expected format=plaintext, got %q", got)
+		}
+		w.Write([]byte(`{"queryresult":{"success":true,"error":false,"numpods":0,"pods":[]}}`))
+	}))
+	defer ts.Close()
+	withTestServer(t, ts)
+
+	r, e := AskQuestionContextOpts(context.Background(), "2+2", nil)
+	if e != nil {
+		t.Fatalf("unexpected error: %v", e)
+	}
+	if r == nil || !r.QueryResult.Success {
+		t.Fatalf("expected a successful result, got %+v", r)
+	}
+	if r.opts != nil {
+		t.Fatalf("expected opts to be recorded as nil, got %+v", r.opts)
+	}
+}
@@ -6,6 +6,7 @@ package wolframalpha
 
 import (
 	"errors"
+	"net/http"
 	"regexp"
 )
 
@@ -16,6 +17,9 @@ const (
 	finderRegex                = `-?\d*\.?\d+(×10\^-?\d+)?`
 	fullResultsURL             = "https://api.wolframalpha.com/v2/query?input=%s&format=plaintext&output=JSON&appid=%s"
 	spokenResultsURL           = "https://api.wolframalpha.com/v1/spoken?i=%s&appid=%s"
+	shortResultsURL            = "https://api.wolframalpha.com/v1/result?i=%s&appid=%s"
+	conversationHost           = "api.wolframalpha.com"
+	conversationPath           = "/v1/conversation.jsp"
 )
 
 var (
@@ -29,6 +33,8 @@ var (
 
 	keepParens = false
 
+	httpClient = http.DefaultClient
+
 	numericalRgx = regexp.MustCompile(numberRegex)
 	numberFinder = regexp.MustCompile(finderRegex)
 	parenRemover = regexp.MustCompile(`\(.*\)`).ReplaceAllString
@@ -38,6 +44,12 @@ var (
 //You should not instantiate this yourself.
 type FullResult struct {
 	QueryResult *QueryResult `json:"queryresult"`
+
+	//query and opts record how this result was obtained so ResolveWith and
+	//BestGuess can re-issue the same query with a chosen assumption or
+	//corrected input. Unexported, so they're ignored by encoding/json.
+	query string
+	opts  *QueryOptions
 }
 
 //QueryResult contains all the actual information about a result
@@ -117,9 +129,33 @@ type SubPod struct {
 	ImageSource  string        `json:"imagesource,omitempty"`
 	DataSources  *DataSources  `json:"datasources,omitempty"`
 	Infos        *SubPodInfos  `json:"infos,omitempty"`
+	Image        *Image        `json:"img,omitempty"`
+	MathML       string        `json:"mathml,omitempty"`
+	Sound        *Sound        `json:"sound,omitempty"`
+	MInput       string        `json:"minput,omitempty"`
+	MOutput      string        `json:"moutput,omitempty"`
 	Primary      bool          `json:"primary,omitempty"`
 }
 
+//Image describes an image rendering of a subpod, as returned when
+//`format=image` is requested.
+//You should not instantiate this yourself.
+type Image struct {
+	Src    string `json:"src"`
+	Alt    string `json:"alt"`
+	Title  string `json:"title,omitempty"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+//Sound describes an audio rendering of a subpod, as returned when
+//`format=sound` or `format=wav` is requested.
+//You should not instantiate this yourself.
+type Sound struct {
+	URL  string `json:"url"`
+	Type string `json:"type,omitempty"`
+}
+
 //MicroSources briefly describe where a bit of information came from
 //You should not instantiate this yourself.
 type MicroSources struct {
@@ -260,3 +296,15 @@ type AssumptionValue struct {
 	Desc  string `json:"desc"`
 	Input string `json:"input"`
 }
+
+//ConversationTurn is one response from the /v1/conversation.jsp API. The next
+//turn must target Host and send ConversationID and S to keep the
+//conversation's context.
+//You should not instantiate this yourself.
+type ConversationTurn struct {
+	Result         string `json:"result"`
+	ConversationID string `json:"conversationID"`
+	Host           string `json:"host"`
+	S              int    `json:"s"`
+	ErrorMsg       string `json:"error,omitempty"`
+}